@@ -0,0 +1,201 @@
+package env
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	value        []byte
+	etag         string
+	lastModified string
+	expiresAt    time.Time
+}
+
+var (
+	remoteCacheMu sync.Mutex
+	remoteCache   = map[string]*cacheEntry{}
+)
+
+// RemoteCached is like Remote, but keeps the fetched bytes in a process-wide
+// cache keyed by the URL and only re-fetches once ttl has elapsed. The
+// refetch is conditional (If-None-Match/If-Modified-Since), so a 304
+// response just extends the cache's lifetime without re-downloading the
+// body. errlog is called, and the last good value returned, if the refetch
+// fails outright.
+func (e Var) RemoteCached(ttl time.Duration, errlog func(key string, err error), opts ...RemoteOption) []byte {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return nil
+	}
+
+	remoteCacheMu.Lock()
+	entry, ok := remoteCache[e.Value]
+	if ok && time.Now().Before(entry.expiresAt) {
+		value := entry.value
+		remoteCacheMu.Unlock()
+		return value
+	}
+	remoteCacheMu.Unlock()
+
+	var etag, lastModified string
+	if ok {
+		etag, lastModified = entry.etag, entry.lastModified
+	}
+
+	res, err := doRemoteRequest(e.Value, etag, lastModified, opts...)
+	if err != nil {
+		errlog(e.Key, err)
+		if ok {
+			return entry.value
+		}
+		return nil
+	}
+	defer res.Body.Close()
+
+	remoteCacheMu.Lock()
+	defer remoteCacheMu.Unlock()
+
+	if res.StatusCode == 304 {
+		if ok {
+			entry.expiresAt = time.Now().Add(ttl)
+			return entry.value
+		}
+		return nil
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		errlog(e.Key, err)
+		if ok {
+			return entry.value
+		}
+		return nil
+	}
+	remoteCache[e.Value] = &cacheEntry{
+		value:        b,
+		etag:         res.Header.Get("ETag"),
+		lastModified: res.Header.Get("Last-Modified"),
+		expiresAt:    time.Now().Add(ttl),
+	}
+	return b
+}
+
+// RemoteWatcher periodically re-fetches the URL held in an env Var and
+// notifies registered callbacks when its contents change, letting
+// long-running services hot-reload config, feature flags, or allowlists
+// without a restart.
+type RemoteWatcher struct {
+	v        Var
+	interval time.Duration
+	errlog   func(key string, err error)
+	opts     []RemoteOption
+
+	mu           sync.Mutex
+	value        []byte
+	etag         string
+	lastModified string
+	callbacks    []func([]byte)
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRemoteWatcher creates a RemoteWatcher for e, polling at the given
+// interval. It does not start polling until Start is called.
+func NewRemoteWatcher(e Var, interval time.Duration, errlog func(key string, err error), opts ...RemoteOption) *RemoteWatcher {
+	return &RemoteWatcher{
+		v:        e,
+		interval: interval,
+		errlog:   errlog,
+		opts:     opts,
+	}
+}
+
+// OnChange registers fn to be called, with the newly fetched bytes, every
+// time a poll observes a changed 200 OK response.
+func (w *RemoteWatcher) OnChange(fn func([]byte)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.callbacks = append(w.callbacks, fn)
+}
+
+// Value returns the most recently fetched bytes, or nil if no successful
+// fetch has completed yet.
+func (w *RemoteWatcher) Value() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.value
+}
+
+// Start fetches once immediately and then launches a goroutine that
+// refetches every interval until Stop is called.
+func (w *RemoteWatcher) Start() {
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	w.poll()
+	go func() {
+		defer close(w.done)
+		t := time.NewTicker(w.interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				w.poll()
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the polling goroutine and waits for it to exit.
+func (w *RemoteWatcher) Stop() {
+	close(w.stop)
+	<-w.done
+}
+
+func (w *RemoteWatcher) poll() {
+	if !w.v.Set {
+		w.errlog(w.v.Key, nil)
+		return
+	}
+
+	w.mu.Lock()
+	etag, lastModified := w.etag, w.lastModified
+	w.mu.Unlock()
+
+	res, err := doRemoteRequest(w.v.Value, etag, lastModified, w.opts...)
+	if err != nil {
+		w.errlog(w.v.Key, err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 304 {
+		return
+	}
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		w.errlog(w.v.Key, err)
+		return
+	}
+
+	w.mu.Lock()
+	changed := !bytes.Equal(w.value, b)
+	w.value = b
+	w.etag = res.Header.Get("ETag")
+	w.lastModified = res.Header.Get("Last-Modified")
+	var callbacks []func([]byte)
+	if changed {
+		callbacks = append([]func([]byte){}, w.callbacks...)
+	}
+	w.mu.Unlock()
+
+	for _, cb := range callbacks {
+		cb(b)
+	}
+}
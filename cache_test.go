@@ -0,0 +1,123 @@
+package env
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRemoteCachedHonorsTTLAndETag(t *testing.T) {
+	var hits, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("hello"))
+	}))
+	defer srv.Close()
+
+	e := Var{Key: "URL", Value: srv.URL, Set: true}
+	var errs []error
+	errlog := func(key string, err error) { errs = append(errs, err) }
+
+	b1 := e.RemoteCached(50*time.Millisecond, errlog)
+	if string(b1) != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", b1)
+	}
+
+	b2 := e.RemoteCached(50*time.Millisecond, errlog)
+	if string(b2) != "hello" {
+		t.Fatalf("expected cached value %q, got %q", "hello", b2)
+	}
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Fatalf("expected 1 request within ttl, got %d", hits)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	b3 := e.RemoteCached(50*time.Millisecond, errlog)
+	if string(b3) != "hello" {
+		t.Fatalf("expected revalidated value %q, got %q", "hello", b3)
+	}
+	if atomic.LoadInt32(&hits) != 2 {
+		t.Fatalf("expected a revalidation request after ttl expiry, got %d total requests", hits)
+	}
+	if atomic.LoadInt32(&notModified) != 1 {
+		t.Fatalf("expected the revalidation to be a conditional request answered 304, got %d", notModified)
+	}
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestRemoteWatcherOnlyNotifiesOnChange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("constant"))
+	}))
+	defer srv.Close()
+
+	e := Var{Key: "URL", Value: srv.URL, Set: true}
+	w := NewRemoteWatcher(e, 5*time.Millisecond, func(key string, err error) {
+		t.Errorf("unexpected errlog call: %v", err)
+	})
+
+	var mu sync.Mutex
+	var calls int
+	w.OnChange(func(b []byte) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	})
+
+	w.Start()
+	time.Sleep(80 * time.Millisecond)
+	w.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 OnChange call for unchanging content, got %d", calls)
+	}
+}
+
+func TestRemoteWatcherNotifiesEachDistinctChange(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := atomic.AddInt32(&n, 1)
+		if v <= 3 {
+			w.Write([]byte("v1"))
+		} else {
+			w.Write([]byte("v2"))
+		}
+	}))
+	defer srv.Close()
+
+	e := Var{Key: "URL", Value: srv.URL, Set: true}
+	w := NewRemoteWatcher(e, 5*time.Millisecond, func(key string, err error) {
+		t.Errorf("unexpected errlog call: %v", err)
+	})
+
+	var mu sync.Mutex
+	var seen []string
+	w.OnChange(func(b []byte) {
+		mu.Lock()
+		seen = append(seen, string(b))
+		mu.Unlock()
+	})
+
+	w.Start()
+	time.Sleep(120 * time.Millisecond)
+	w.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != 2 || seen[0] != "v1" || seen[1] != "v2" {
+		t.Fatalf("expected exactly [v1 v2], got %v", seen)
+	}
+}
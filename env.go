@@ -1,9 +1,6 @@
 package env
 
 import (
-	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -22,6 +19,14 @@ func Get(key string) Var {
 	return Var{Key: key, Value: value, Set: set}
 }
 
+// GetOrFile is like Get, but if KEY is unset it falls back to reading the
+// path held in KEY_FILE and uses its trimmed contents as the value. This
+// mirrors the common Docker/Kubernetes convention for mounting secrets as
+// files, letting callers accept either form without special-casing it.
+func GetOrFile(key string) Var {
+	return Get(key).OrFile()
+}
+
 // WithDefault returns the value of the environment variable if it is set.
 // Otherwise it returns the provided default value.
 func (e Var) WithDefault(value string) string {
@@ -58,34 +63,40 @@ func (e Var) WithDefaultInt(value int, errlog func(key string, parseErr error))
 	return v
 }
 
+// OrFile returns e unchanged if it is already set. Otherwise it looks up
+// KEY_FILE, reads that path, and returns a Var with Set=true and Value set
+// to the trimmed file contents. If KEY_FILE is also unset, or the file
+// cannot be read, e is returned unchanged.
+func (e Var) OrFile() Var {
+	if e.Set {
+		return e
+	}
+	fileVar := Get(e.Key + "_FILE")
+	if !fileVar.Set {
+		return e
+	}
+	value, err := readFileTrim(fileVar.Value)
+	if err != nil {
+		return e
+	}
+	return Var{Key: e.Key, Value: value, Set: true}
+}
+
 // List returns the individual values of a comma separated list from a Var.
 func (e Var) List(sep string) []string {
 	return strings.Split(e.Value, sep)
 }
 
-func (e Var) Remote(errlog func(key string, err error)) []byte {
+// Remote treats the Var's value as a URL and fetches its contents over
+// HTTP(S). Pass RemoteOptions (WithHTTPClient, WithTimeout, WithHeader,
+// WithNetrc) to authenticate or harden the request; see remote.go.
+func (e Var) Remote(errlog func(key string, err error), opts ...RemoteOption) []byte {
 	if !e.Set {
 		errlog(e.Key, nil)
 	}
-	b, err := readURL(e.Value)
+	b, err := readURL(e.Value, opts...)
 	if err != nil {
 		errlog(e.Key, err)
 	}
 	return b
 }
-
-func readURL(url string) ([]byte, error) {
-	res, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("error getting from remote: %v", err)
-	}
-	defer res.Body.Close()
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("bad status getting from remote: %v", res.StatusCode)
-	}
-	b, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading: %v", err)
-	}
-	return b, nil
-}
@@ -0,0 +1,51 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetOrFileKeySet(t *testing.T) {
+	setenv(t, "ORFILE_SET", "inline-value")
+	os.Unsetenv("ORFILE_SET_FILE")
+
+	v := GetOrFile("ORFILE_SET")
+	if !v.Set || v.Value != "inline-value" {
+		t.Fatalf("GetOrFile = %+v, want Set=true Value=%q", v, "inline-value")
+	}
+}
+
+func TestGetOrFileFallsBackToFile(t *testing.T) {
+	os.Unsetenv("ORFILE_FALLBACK")
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, "ORFILE_FALLBACK_FILE", path)
+
+	v := GetOrFile("ORFILE_FALLBACK")
+	if !v.Set || v.Value != "from-file" {
+		t.Fatalf("GetOrFile = %+v, want Set=true Value=%q (trimmed)", v, "from-file")
+	}
+}
+
+func TestOrFileNoFileVarIsPassthrough(t *testing.T) {
+	os.Unsetenv("ORFILE_NOFILE")
+	os.Unsetenv("ORFILE_NOFILE_FILE")
+
+	v := Get("ORFILE_NOFILE").OrFile()
+	if v.Set {
+		t.Fatalf("OrFile = %+v, want Set=false when neither KEY nor KEY_FILE is set", v)
+	}
+}
+
+func TestOrFileUnreadablePathIsPassthrough(t *testing.T) {
+	os.Unsetenv("ORFILE_BADPATH")
+	setenv(t, "ORFILE_BADPATH_FILE", filepath.Join(t.TempDir(), "does-not-exist"))
+
+	v := Get("ORFILE_BADPATH").OrFile()
+	if v.Set {
+		t.Fatalf("OrFile = %+v, want Set=false when KEY_FILE points at an unreadable path", v)
+	}
+}
@@ -0,0 +1,17 @@
+package env
+
+import (
+	"io/ioutil"
+	"strings"
+)
+
+// readFileTrim reads the file at path and returns its contents with
+// trailing whitespace removed, matching the convention used by
+// Docker/Kubernetes secret mounts.
+func readFileTrim(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(b), " \t\r\n"), nil
+}
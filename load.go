@@ -0,0 +1,137 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type envLine struct {
+	key    string
+	value  string
+	quoted bool // single-quoted: value is literal, no ${OTHER} expansion
+}
+
+// LoadFile parses a dotenv-style KEY=VALUE file and calls os.Setenv for
+// every key not already present in the process environment, so that
+// subsequent Get calls see them. Lines starting with # are comments, blank
+// lines are ignored, values may be wrapped in matching single or double
+// quotes, and ${OTHER} references expand to the value of OTHER as resolved
+// so far (including earlier lines in the same file) — except inside
+// single-quoted values, which are taken literally. The file is parsed in
+// full before any variable is set, so a malformed line never leaves the
+// process environment partially updated.
+func LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var lines []envLine
+	sc := bufio.NewScanner(f)
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("env: %s:%d: missing '=' in %q", path, lineNum, line)
+		}
+		key := strings.TrimSpace(parts[0])
+		if key == "" {
+			return fmt.Errorf("env: %s:%d: missing key in %q", path, lineNum, line)
+		}
+		value, quoted := unquote(strings.TrimSpace(parts[1]))
+		lines = append(lines, envLine{
+			key:    key,
+			value:  value,
+			quoted: quoted,
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		value := l.value
+		if !l.quoted {
+			value = expandBraces(value)
+		}
+		if _, set := os.LookupEnv(l.key); !set {
+			if err := os.Setenv(l.key, value); err != nil {
+				return fmt.Errorf("env: %s: %v", path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// unquote strips a single layer of matching single or double quotes from
+// value, reporting whether the quotes removed were single quotes.
+func unquote(value string) (unquoted string, singleQuoted bool) {
+	if len(value) < 2 {
+		return value, false
+	}
+	quote := value[0]
+	if (quote == '"' || quote == '\'') && value[len(value)-1] == quote {
+		return value[1 : len(value)-1], quote == '\''
+	}
+	return value, false
+}
+
+// expandBraces replaces every ${NAME} reference in value with NAME's
+// current environment value, leaving bare $NAME untouched.
+func expandBraces(value string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			b.WriteString(value)
+			break
+		}
+		end := strings.IndexByte(value[start:], '}')
+		if end == -1 {
+			b.WriteString(value)
+			break
+		}
+		end += start
+		b.WriteString(value[:start])
+		b.WriteString(Get(value[start+2 : end]).Value)
+		value = value[end+1:]
+	}
+	return b.String()
+}
+
+// LoadDefault loads the process's default env file, following the Go
+// toolchain's GOENV convention: it honors $GOENV (where "off" disables
+// loading entirely), and otherwise falls back to
+// os.UserConfigDir()/<app>/env, where <app> is the running binary's name.
+// A missing default file is not an error.
+func LoadDefault() error {
+	path := Get("GOENV")
+	if path.Set {
+		if path.Value == "off" {
+			return nil
+		}
+		return loadFileIfExists(path.Value)
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return err
+	}
+	app := filepath.Base(os.Args[0])
+	return loadFileIfExists(filepath.Join(dir, app, "env"))
+}
+
+func loadFileIfExists(path string) error {
+	err := LoadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeEnvFile(t *testing.T, contents string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "env")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFileBasics(t *testing.T) {
+	os.Unsetenv("LOAD_A")
+	os.Unsetenv("LOAD_B")
+	os.Unsetenv("LOAD_C")
+	path := writeEnvFile(t, `
+# a comment
+LOAD_A=plain
+
+LOAD_B="double quoted"
+LOAD_C='single quoted'
+`)
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_A"); v != "plain" {
+		t.Errorf("LOAD_A = %q, want %q", v, "plain")
+	}
+	if v := os.Getenv("LOAD_B"); v != "double quoted" {
+		t.Errorf("LOAD_B = %q, want %q", v, "double quoted")
+	}
+	if v := os.Getenv("LOAD_C"); v != "single quoted" {
+		t.Errorf("LOAD_C = %q, want %q", v, "single quoted")
+	}
+}
+
+func TestLoadFileDoesNotOverrideExisting(t *testing.T) {
+	setenv(t, "LOAD_EXISTING", "from-process")
+	path := writeEnvFile(t, "LOAD_EXISTING=from-file\n")
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_EXISTING"); v != "from-process" {
+		t.Errorf("LOAD_EXISTING = %q, want existing value preserved: %q", v, "from-process")
+	}
+}
+
+func TestLoadFileExpandsBraces(t *testing.T) {
+	os.Unsetenv("LOAD_BASE")
+	os.Unsetenv("LOAD_DERIVED")
+	path := writeEnvFile(t, "LOAD_BASE=postgres://db\nLOAD_DERIVED=${LOAD_BASE}/mydb\n")
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_DERIVED"); v != "postgres://db/mydb" {
+		t.Errorf("LOAD_DERIVED = %q, want %q", v, "postgres://db/mydb")
+	}
+}
+
+func TestLoadFileSingleQuotesAreLiteral(t *testing.T) {
+	os.Unsetenv("LOAD_LITERAL")
+	os.Unsetenv("word")
+	path := writeEnvFile(t, `LOAD_LITERAL='p@ss$word'` + "\n")
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_LITERAL"); v != "p@ss$word" {
+		t.Errorf("LOAD_LITERAL = %q, want literal %q (single quotes must disable expansion)", v, "p@ss$word")
+	}
+}
+
+func TestLoadFileBareDollarIsNotExpanded(t *testing.T) {
+	os.Unsetenv("LOAD_BARE")
+	os.Unsetenv("word")
+	path := writeEnvFile(t, "LOAD_BARE=p@ss$word\n")
+	if err := LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_BARE"); v != "p@ss$word" {
+		t.Errorf("LOAD_BARE = %q, want literal %q (only ${NAME} should expand)", v, "p@ss$word")
+	}
+}
+
+func TestLoadFileMalformedLineLeavesEnvironmentUntouched(t *testing.T) {
+	os.Unsetenv("LOAD_BEFORE_ERROR")
+	path := writeEnvFile(t, "LOAD_BEFORE_ERROR=set-me\nnotakeyvalueline\n")
+	if err := LoadFile(path); err == nil {
+		t.Fatalf("expected an error for the malformed line")
+	}
+	if v, set := os.LookupEnv("LOAD_BEFORE_ERROR"); set {
+		t.Errorf("LOAD_BEFORE_ERROR = %q, want unset after a later parse error", v)
+	}
+}
+
+func TestLoadFileMissingPath(t *testing.T) {
+	err := LoadFile(filepath.Join(t.TempDir(), "does-not-exist"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected a not-exist error, got %v", err)
+	}
+}
+
+func TestLoadDefaultGOENVOff(t *testing.T) {
+	setenv(t, "GOENV", "off")
+	if err := LoadDefault(); err != nil {
+		t.Fatalf("LoadDefault with GOENV=off returned error: %v", err)
+	}
+}
+
+func TestLoadDefaultGOENVMissingFileIsNotAnError(t *testing.T) {
+	setenv(t, "GOENV", filepath.Join(t.TempDir(), "does-not-exist"))
+	if err := LoadDefault(); err != nil {
+		t.Fatalf("expected a missing GOENV file to be ignored, got: %v", err)
+	}
+}
+
+func TestLoadDefaultGOENVExplicitFile(t *testing.T) {
+	os.Unsetenv("LOAD_DEFAULT_VIA_GOENV")
+	path := writeEnvFile(t, "LOAD_DEFAULT_VIA_GOENV=yes\n")
+	setenv(t, "GOENV", path)
+	if err := LoadDefault(); err != nil {
+		t.Fatalf("LoadDefault returned error: %v", err)
+	}
+	if v := os.Getenv("LOAD_DEFAULT_VIA_GOENV"); v != "yes" {
+		t.Errorf("LOAD_DEFAULT_VIA_GOENV = %q, want %q", v, "yes")
+	}
+}
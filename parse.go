@@ -0,0 +1,252 @@
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError reports every field that could not be populated by Parse,
+// rather than stopping at the first one.
+type ParseError struct {
+	Errors []error
+}
+
+func (e *ParseError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("env: %d error(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Parse populates the exported fields of the struct pointed to by v from
+// environment variables, based on the following struct tags:
+//
+//  env:"KEY"            the environment variable to read, required for
+//                        non-struct fields
+//  env:"KEY,required"    fail if KEY is unset
+//  envDefault:"value"    value to use if KEY is unset
+//  envFile:"path"        file to fall back to if KEY is unset
+//  envSeparator:","      separator to use when parsing slice fields (default ",")
+//  envPrefix:"PREFIX_"   prefix prepended to the env key of every field of a
+//                        nested struct
+//
+// Every missing or unparseable variable is collected and returned together
+// as a *ParseError, rather than failing on the first one.
+//
+// Struct-kind fields other than url.URL are treated as nested structs to
+// recurse into. A struct type with no exported fields (such as time.Time)
+// has none to populate, so it is reported as a *ParseError rather than
+// silently ignored.
+func Parse(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("env: Parse requires a non-nil pointer to a struct, got %T", v)
+	}
+	var errs []error
+	parseStruct(rv.Elem(), "", &errs)
+	if len(errs) > 0 {
+		return &ParseError{Errors: errs}
+	}
+	return nil
+}
+
+// MustParse is like Parse but panics if an error is returned.
+func MustParse(v interface{}) {
+	if err := Parse(v); err != nil {
+		panic(err)
+	}
+}
+
+func parseStruct(rv reflect.Value, prefix string, errs *[]error) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct && fv.Type().Elem() != reflect.TypeOf(url.URL{}) {
+			if !hasExportedField(fv.Type().Elem()) {
+				*errs = append(*errs, fmt.Errorf("%s: struct type %s has no exported fields to populate; use a scalar field with a custom parse step instead", prefix+field.Name, fv.Type().Elem()))
+				continue
+			}
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			parseNestedOrField(field, fv.Elem(), prefix, errs)
+			continue
+		}
+		if fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(url.URL{}) {
+			if !hasExportedField(fv.Type()) {
+				*errs = append(*errs, fmt.Errorf("%s: struct type %s has no exported fields to populate; use a scalar field with a custom parse step instead", prefix+field.Name, fv.Type()))
+				continue
+			}
+			parseNestedOrField(field, fv, prefix, errs)
+			continue
+		}
+
+		key, opts := parseTag(field.Tag.Get("env"))
+		if key == "" {
+			continue
+		}
+		key = prefix + key
+
+		if err := setField(fv, key, field.Tag, opts); err != nil {
+			*errs = append(*errs, err)
+		}
+	}
+}
+
+func parseNestedOrField(field reflect.StructField, fv reflect.Value, prefix string, errs *[]error) {
+	childPrefix := prefix + field.Tag.Get("envPrefix")
+	parseStruct(fv, childPrefix, errs)
+}
+
+// hasExportedField reports whether t, a struct type, has at least one
+// exported field reachable for Parse to populate — directly, or through
+// exported nested structs. Types like time.Time, whose fields are all
+// unexported, return false, letting callers surface an error instead of
+// silently dropping the field.
+func hasExportedField(t reflect.Type) bool {
+	if t == reflect.TypeOf(url.URL{}) {
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		if field.Type.Kind() == reflect.Struct && hasExportedField(field.Type) {
+			return true
+		}
+		if field.Type.Kind() != reflect.Struct {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTag(tag string) (key string, opts []string) {
+	parts := strings.Split(tag, ",")
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return parts[0], parts[1:]
+}
+
+func hasOpt(opts []string, name string) bool {
+	for _, o := range opts {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+func setField(fv reflect.Value, key string, tag reflect.StructTag, opts []string) error {
+	e := Get(key)
+	value := e.Value
+	if !e.Set {
+		if path := tag.Get("envFile"); path != "" {
+			if s, err := readFileTrim(path); err == nil {
+				value = s
+				e.Set = true
+			}
+		}
+	}
+	if !e.Set {
+		if def, ok := tag.Lookup("envDefault"); ok {
+			value = def
+			e.Set = true
+		}
+	}
+	if !e.Set {
+		if hasOpt(opts, "required") {
+			return fmt.Errorf("%s: required environment variable is not set", key)
+		}
+		return nil
+	}
+
+	sep := tag.Get("envSeparator")
+	if sep == "" {
+		sep = ","
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+
+	if fv.Kind() == reflect.Slice {
+		parts := strings.Split(value, sep)
+		out := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, p := range parts {
+			if err := setScalar(out.Index(i), key, strings.TrimSpace(p)); err != nil {
+				return err
+			}
+		}
+		fv.Set(out)
+		return nil
+	}
+
+	return setScalar(fv, key, value)
+}
+
+func setScalar(fv reflect.Value, key, value string) error {
+	if fv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	if fv.Type() == reflect.TypeOf(url.URL{}) {
+		u, err := url.Parse(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.Set(reflect.ValueOf(*u))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s: %v", key, err)
+		}
+		fv.SetFloat(f)
+	default:
+		return fmt.Errorf("%s: unsupported field type %s", key, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package env
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	old, had := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if had {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestParseBasicFields(t *testing.T) {
+	setenv(t, "PARSE_STR", "hello")
+	setenv(t, "PARSE_INT", "42")
+	setenv(t, "PARSE_BOOL", "true")
+	setenv(t, "PARSE_DUR", "1500ms")
+	setenv(t, "PARSE_URL", "https://example.com/path")
+
+	var cfg struct {
+		Str  string        `env:"PARSE_STR"`
+		Int  int           `env:"PARSE_INT"`
+		Bool bool          `env:"PARSE_BOOL"`
+		Dur  time.Duration `env:"PARSE_DUR"`
+		URL  url.URL       `env:"PARSE_URL"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if cfg.Str != "hello" {
+		t.Errorf("Str = %q, want %q", cfg.Str, "hello")
+	}
+	if cfg.Int != 42 {
+		t.Errorf("Int = %d, want 42", cfg.Int)
+	}
+	if !cfg.Bool {
+		t.Errorf("Bool = false, want true")
+	}
+	if cfg.Dur != 1500*time.Millisecond {
+		t.Errorf("Dur = %v, want 1.5s", cfg.Dur)
+	}
+	if cfg.URL.Host != "example.com" {
+		t.Errorf("URL.Host = %q, want %q", cfg.URL.Host, "example.com")
+	}
+}
+
+func TestParseDefaultAndRequired(t *testing.T) {
+	os.Unsetenv("PARSE_MISSING_REQUIRED")
+	os.Unsetenv("PARSE_MISSING_DEFAULT")
+
+	var cfg struct {
+		Required string `env:"PARSE_MISSING_REQUIRED,required"`
+		Default  string `env:"PARSE_MISSING_DEFAULT" envDefault:"fallback"`
+	}
+	err := Parse(&cfg)
+	if err == nil {
+		t.Fatalf("expected an error for the missing required field")
+	}
+	if cfg.Default != "fallback" {
+		t.Errorf("Default = %q, want %q", cfg.Default, "fallback")
+	}
+
+	perr, ok := err.(*ParseError)
+	if !ok || len(perr.Errors) != 1 {
+		t.Fatalf("expected a *ParseError with 1 error, got %#v", err)
+	}
+}
+
+func TestParseAggregatesAllErrors(t *testing.T) {
+	os.Unsetenv("PARSE_AGG_A")
+	os.Unsetenv("PARSE_AGG_B")
+	setenv(t, "PARSE_AGG_C", "not-an-int")
+
+	var cfg struct {
+		A string `env:"PARSE_AGG_A,required"`
+		B string `env:"PARSE_AGG_B,required"`
+		C int    `env:"PARSE_AGG_C"`
+	}
+	err := Parse(&cfg)
+	perr, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expected a *ParseError, got %#v", err)
+	}
+	if len(perr.Errors) != 3 {
+		t.Fatalf("expected all 3 fields to fail independently, got %d: %v", len(perr.Errors), perr.Errors)
+	}
+}
+
+func TestParseEnvFileFallback(t *testing.T) {
+	os.Unsetenv("PARSE_FILE_KEY")
+
+	var cfg struct {
+		Key string `env:"PARSE_FILE_KEY" envFile:"testdata/parse_envfile_secret"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if cfg.Key != "s3cr3t" {
+		t.Fatalf("Key = %q, want %q", cfg.Key, "s3cr3t")
+	}
+}
+
+func TestParseSlice(t *testing.T) {
+	setenv(t, "PARSE_SLICE", "a, b ,c")
+
+	var cfg struct {
+		Items []string `env:"PARSE_SLICE"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	want := []string{"a", "b", "c"}
+	if len(cfg.Items) != len(want) {
+		t.Fatalf("Items = %v, want %v", cfg.Items, want)
+	}
+	for i := range want {
+		if cfg.Items[i] != want[i] {
+			t.Fatalf("Items = %v, want %v", cfg.Items, want)
+		}
+	}
+}
+
+func TestParseSliceCustomSeparator(t *testing.T) {
+	setenv(t, "PARSE_SLICE_SEP", "1|2|3")
+
+	var cfg struct {
+		Items []int `env:"PARSE_SLICE_SEP" envSeparator:"|"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if len(cfg.Items) != 3 || cfg.Items[0] != 1 || cfg.Items[2] != 3 {
+		t.Fatalf("Items = %v, want [1 2 3]", cfg.Items)
+	}
+}
+
+func TestParseNestedStructWithPrefix(t *testing.T) {
+	setenv(t, "PARSE_DB_HOST", "localhost")
+	setenv(t, "PARSE_DB_PORT", "5432")
+
+	type db struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+	var cfg struct {
+		DB db `envPrefix:"PARSE_DB_"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 {
+		t.Fatalf("DB = %+v, want {localhost 5432}", cfg.DB)
+	}
+}
+
+func TestParsePointerField(t *testing.T) {
+	setenv(t, "PARSE_PTR", "7")
+
+	var cfg struct {
+		Count *int `env:"PARSE_PTR"`
+	}
+	if err := Parse(&cfg); err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if cfg.Count == nil || *cfg.Count != 7 {
+		t.Fatalf("Count = %v, want pointer to 7", cfg.Count)
+	}
+}
+
+func TestParseReportsStructWithNoExportedFields(t *testing.T) {
+	setenv(t, "PARSE_TIME", "2020-01-01T00:00:00Z")
+
+	var cfg struct {
+		Created time.Time `env:"PARSE_TIME"`
+	}
+	err := Parse(&cfg)
+	if err == nil {
+		t.Fatalf("expected an error for a time.Time field, got nil (silently dropped)")
+	}
+	perr, ok := err.(*ParseError)
+	if !ok || len(perr.Errors) != 1 {
+		t.Fatalf("expected a *ParseError with 1 error, got %#v", err)
+	}
+}
+
+func TestParseRejectsNonPointer(t *testing.T) {
+	var cfg struct {
+		Str string `env:"PARSE_REJECT"`
+	}
+	if err := Parse(cfg); err == nil {
+		t.Fatalf("expected an error when v is not a pointer")
+	}
+}
+
+func TestMustParsePanicsOnError(t *testing.T) {
+	os.Unsetenv("PARSE_MUST_MISSING")
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected MustParse to panic")
+		}
+	}()
+	var cfg struct {
+		Required string `env:"PARSE_MUST_MISSING,required"`
+	}
+	MustParse(&cfg)
+}
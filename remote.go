@@ -0,0 +1,224 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RemoteOption configures how Var.Remote fetches its URL.
+type RemoteOption func(*remoteConfig)
+
+type remoteConfig struct {
+	client  *http.Client
+	timeout time.Duration
+	headers http.Header
+	netrc   map[string]netrcEntry
+}
+
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+// WithHTTPClient sets the *http.Client used for the request, letting callers
+// reuse connection pools, proxies, or transports across calls.
+func WithHTTPClient(c *http.Client) RemoteOption {
+	return func(cfg *remoteConfig) { cfg.client = c }
+}
+
+// WithTimeout sets a timeout for the request. If combined with
+// WithHTTPClient, it applies only when that client's own Timeout is zero.
+func WithTimeout(d time.Duration) RemoteOption {
+	return func(cfg *remoteConfig) { cfg.timeout = d }
+}
+
+// WithHeader adds a header to the request, e.g. for bearer tokens:
+//  e.Remote(errlog, env.WithHeader("Authorization", "Bearer "+token))
+func WithHeader(key, value string) RemoteOption {
+	return func(cfg *remoteConfig) {
+		if cfg.headers == nil {
+			cfg.headers = http.Header{}
+		}
+		cfg.headers.Add(key, value)
+	}
+}
+
+// WithNetrc loads host credentials from a netrc file and injects Basic auth
+// for any request host found there, matching the mechanism Go's own
+// cmd/go/internal/auth uses for module fetches. If path is empty, it
+// follows the same resolution as `curl`/`go`: $NETRC if set, else
+// .netrc in the user's home directory (_netrc on Windows).
+func WithNetrc(path string) RemoteOption {
+	return func(cfg *remoteConfig) {
+		if path == "" {
+			path = netrcPath()
+		}
+		entries, err := parseNetrc(path)
+		if err != nil {
+			return
+		}
+		cfg.netrc = entries
+	}
+}
+
+func netrcPath() string {
+	if p := Get("NETRC").Value; p != "" {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	name := ".netrc"
+	if os.PathSeparator == '\\' {
+		name = "_netrc"
+	}
+	return filepath.Join(home, name)
+}
+
+// parseNetrc reads the minimal subset of the netrc format needed to map a
+// machine name to a login/password pair: "machine HOST login L password P".
+func parseNetrc(path string) (map[string]netrcEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := map[string]netrcEntry{}
+	var machine string
+	var entry netrcEntry
+
+	flush := func() {
+		if machine != "" {
+			entries[machine] = entry
+		}
+		machine, entry = "", netrcEntry{}
+	}
+
+	sc := bufio.NewScanner(f)
+	sc.Split(bufio.ScanWords)
+	var prev string
+	for sc.Scan() {
+		tok := sc.Text()
+		switch prev {
+		case "machine":
+			flush()
+			machine = tok
+		case "login":
+			entry.login = tok
+		case "password":
+			entry.password = tok
+		}
+		prev = tok
+	}
+	flush()
+	return entries, sc.Err()
+}
+
+func readURL(rawurl string, opts ...RemoteOption) ([]byte, error) {
+	res, err := doRemoteRequest(rawurl, "", "", opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading: %v", err)
+	}
+	return b, nil
+}
+
+// doRemoteRequest issues a GET to rawurl, optionally as a conditional
+// request via If-None-Match/If-Modified-Since when etag/lastModified are
+// non-empty. Callers are responsible for closing the returned response
+// body. Any status other than 200 or 304 is reported as an error.
+func doRemoteRequest(rawurl, etag, lastModified string, opts ...RemoteOption) (*http.Response, error) {
+	cfg := &remoteConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Always operate on a private copy of the client: callers may pass a
+	// shared *http.Client via WithHTTPClient, and it would be unsafe (both
+	// racy and surprising) to mutate their Timeout/CheckRedirect in place.
+	var clientCopy http.Client
+	if cfg.client != nil {
+		clientCopy = *cfg.client
+	}
+	client := &clientCopy
+	if cfg.timeout > 0 && client.Timeout == 0 {
+		client.Timeout = cfg.timeout
+	}
+	if client.CheckRedirect == nil {
+		client.CheckRedirect = cfg.checkRedirect
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building request for remote: %v", err)
+	}
+	for k, vs := range cfg.headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+	cfg.applyNetrc(req)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting from remote: %v", err)
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotModified {
+		res.Body.Close()
+		return nil, fmt.Errorf("bad status getting from remote: %v", res.StatusCode)
+	}
+	return res, nil
+}
+
+func (cfg *remoteConfig) applyNetrc(req *http.Request) {
+	if cfg.netrc == nil {
+		return
+	}
+	if entry, ok := cfg.netrc[req.URL.Hostname()]; ok {
+		req.SetBasicAuth(entry.login, entry.password)
+	}
+}
+
+// checkRedirect refuses HTTPS->HTTP downgrades and only re-applies netrc
+// auth (and the configured headers) when the redirect stays on the same
+// host, so credentials are never leaked to a third-party redirect target.
+func (cfg *remoteConfig) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after 10 redirects")
+	}
+	prev := via[len(via)-1]
+	if prev.URL.Scheme == "https" && req.URL.Scheme == "http" {
+		return fmt.Errorf("refusing to follow https->http redirect from %s to %s", prev.URL, req.URL)
+	}
+	if req.URL.Hostname() != prev.URL.Hostname() {
+		req.Header.Del("Authorization")
+		for k := range cfg.headers {
+			req.Header.Del(k)
+		}
+		return nil
+	}
+	for k, vs := range cfg.headers {
+		for _, v := range vs {
+			req.Header.Set(k, v)
+		}
+	}
+	cfg.applyNetrc(req)
+	return nil
+}
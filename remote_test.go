@@ -0,0 +1,103 @@
+package env
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRemoteDoesNotMutateSharedClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{}
+	e := Var{Key: "URL", Value: srv.URL, Set: true}
+
+	var errlogErr error
+	e.Remote(func(key string, err error) { errlogErr = err }, WithHTTPClient(client))
+	if errlogErr != nil {
+		t.Fatalf("Remote returned error: %v", errlogErr)
+	}
+	if client.CheckRedirect != nil {
+		t.Fatalf("Remote mutated the caller's shared *http.Client.CheckRedirect")
+	}
+	if client.Timeout != 0 {
+		t.Fatalf("Remote mutated the caller's shared *http.Client.Timeout")
+	}
+}
+
+func TestRemoteRefusesHTTPSToHTTPDowngrade(t *testing.T) {
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("insecure"))
+	}))
+	defer httpSrv.Close()
+
+	httpsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, httpSrv.URL, http.StatusFound)
+	}))
+	defer httpsSrv.Close()
+
+	client := httpsSrv.Client()
+	e := Var{Key: "URL", Value: httpsSrv.URL, Set: true}
+
+	var errlogErr error
+	b := e.Remote(func(key string, err error) { errlogErr = err }, WithHTTPClient(client))
+	if errlogErr == nil {
+		t.Fatalf("expected an error refusing the https->http redirect, got nil (body: %q)", b)
+	}
+}
+
+func TestRemoteReappliesHeaderOnSameHostRedirect(t *testing.T) {
+	var sawAuthOnFinal string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/final", http.StatusFound)
+	})
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) {
+		sawAuthOnFinal = r.Header.Get("Authorization")
+		w.Write([]byte("ok"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	e := Var{Key: "URL", Value: srv.URL + "/redirect", Set: true}
+	var errlogErr error
+	e.Remote(func(key string, err error) { errlogErr = err }, WithHeader("Authorization", "Bearer tok"))
+	if errlogErr != nil {
+		t.Fatalf("Remote returned error: %v", errlogErr)
+	}
+	if sawAuthOnFinal != "Bearer tok" {
+		t.Fatalf("expected Authorization header to be re-applied on same-host redirect, got %q", sawAuthOnFinal)
+	}
+}
+
+func TestRemoteStripsCustomHeaderOnCrossHostRedirect(t *testing.T) {
+	var sawAPIKey string
+	var sawAPIKeySet bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawAPIKey, sawAPIKeySet = r.Header.Get("X-Api-Key"), r.Header.Get("X-Api-Key") != ""
+		w.Write([]byte("ok"))
+	}))
+	defer target.Close()
+
+	// Redirect to a different hostname (localhost vs 127.0.0.1) pointing at
+	// the same listener, so the cross-host branch of checkRedirect fires.
+	crossHostTarget := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, crossHostTarget, http.StatusFound)
+	}))
+	defer srv.Close()
+
+	e := Var{Key: "URL", Value: srv.URL, Set: true}
+	var errlogErr error
+	e.Remote(func(key string, err error) { errlogErr = err }, WithHeader("X-Api-Key", "super-secret"))
+	if errlogErr != nil {
+		t.Fatalf("Remote returned error: %v", errlogErr)
+	}
+	if sawAPIKeySet {
+		t.Fatalf("X-Api-Key leaked to cross-host redirect target, got %q", sawAPIKey)
+	}
+}
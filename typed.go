@@ -0,0 +1,136 @@
+package env
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WithDefaultBool attempts to read a bool from the Var, returns value if
+// Var is unset, and calls errlog if the Var is set to something that is not
+// parsable as a bool.
+func (e Var) WithDefaultBool(value bool, errlog func(key string, parseErr error)) bool {
+	if !e.Set {
+		return value
+	}
+	v, err := strconv.ParseBool(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// WithDefaultFloat64 attempts to read a float64 from the Var, returns value
+// if Var is unset, and calls errlog if the Var is set to something that is
+// not parsable as a float64.
+func (e Var) WithDefaultFloat64(value float64, errlog func(key string, parseErr error)) float64 {
+	if !e.Set {
+		return value
+	}
+	v, err := strconv.ParseFloat(e.Value, 64)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// WithDefaultDuration attempts to read a time.Duration from the Var, returns
+// value if Var is unset, and calls errlog if the Var is set to something
+// that is not parsable as a time.Duration.
+func (e Var) WithDefaultDuration(value time.Duration, errlog func(key string, parseErr error)) time.Duration {
+	if !e.Set {
+		return value
+	}
+	v, err := time.ParseDuration(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// WithDefaultURL attempts to read a *url.URL from the Var, returns value if
+// Var is unset, and calls errlog if the Var is set to something that is not
+// parsable as a URL.
+func (e Var) WithDefaultURL(value *url.URL, errlog func(key string, parseErr error)) *url.URL {
+	if !e.Set {
+		return value
+	}
+	v, err := url.Parse(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// RequiredInt returns the Var parsed as an int. It calls errlog if the Var
+// is unset, or if it is set to something that is not parsable as an int.
+func (e Var) RequiredInt(errlog func(key string, parseErr error)) int {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return 0
+	}
+	v, err := strconv.Atoi(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// RequiredBool returns the Var parsed as a bool. It calls errlog if the Var
+// is unset, or if it is set to something that is not parsable as a bool.
+func (e Var) RequiredBool(errlog func(key string, parseErr error)) bool {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return false
+	}
+	v, err := strconv.ParseBool(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// RequiredFloat64 returns the Var parsed as a float64. It calls errlog if
+// the Var is unset, or if it is set to something that is not parsable as a
+// float64.
+func (e Var) RequiredFloat64(errlog func(key string, parseErr error)) float64 {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return 0
+	}
+	v, err := strconv.ParseFloat(e.Value, 64)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// RequiredDuration returns the Var parsed as a time.Duration. It calls
+// errlog if the Var is unset, or if it is set to something that is not
+// parsable as a time.Duration.
+func (e Var) RequiredDuration(errlog func(key string, parseErr error)) time.Duration {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return 0
+	}
+	v, err := time.ParseDuration(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+	}
+	return v
+}
+
+// RequiredURL returns the Var parsed as a *url.URL. It calls errlog if the
+// Var is unset, or if it is set to something that is not parsable as a URL.
+func (e Var) RequiredURL(errlog func(key string, parseErr error)) *url.URL {
+	if !e.Set {
+		errlog(e.Key, nil)
+		return nil
+	}
+	v, err := url.Parse(e.Value)
+	if err != nil {
+		errlog(e.Key, err)
+		return nil
+	}
+	return v
+}
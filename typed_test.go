@@ -0,0 +1,200 @@
+package env
+
+import (
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWithDefaultBool(t *testing.T) {
+	os.Unsetenv("TYPED_BOOL_UNSET")
+	if v := Get("TYPED_BOOL_UNSET").WithDefaultBool(true, failOnErrlog(t)); v != true {
+		t.Errorf("unset: got %v, want default true", v)
+	}
+
+	setenv(t, "TYPED_BOOL_OK", "false")
+	if v := Get("TYPED_BOOL_OK").WithDefaultBool(true, failOnErrlog(t)); v != false {
+		t.Errorf("parsed: got %v, want false", v)
+	}
+
+	setenv(t, "TYPED_BOOL_BAD", "not-a-bool")
+	var errored bool
+	v := Get("TYPED_BOOL_BAD").WithDefaultBool(true, func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called for an unparseable bool")
+	}
+	if v != false {
+		t.Errorf("parse error: got %v, want zero value false", v)
+	}
+}
+
+func TestWithDefaultFloat64(t *testing.T) {
+	os.Unsetenv("TYPED_FLOAT_UNSET")
+	if v := Get("TYPED_FLOAT_UNSET").WithDefaultFloat64(1.5, failOnErrlog(t)); v != 1.5 {
+		t.Errorf("unset: got %v, want default 1.5", v)
+	}
+
+	setenv(t, "TYPED_FLOAT_OK", "3.25")
+	if v := Get("TYPED_FLOAT_OK").WithDefaultFloat64(1.5, failOnErrlog(t)); v != 3.25 {
+		t.Errorf("parsed: got %v, want 3.25", v)
+	}
+
+	setenv(t, "TYPED_FLOAT_BAD", "not-a-float")
+	var errored bool
+	v := Get("TYPED_FLOAT_BAD").WithDefaultFloat64(1.5, func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called for an unparseable float64")
+	}
+	if v != 0 {
+		t.Errorf("parse error: got %v, want zero value 0", v)
+	}
+}
+
+func TestWithDefaultDuration(t *testing.T) {
+	os.Unsetenv("TYPED_DUR_UNSET")
+	if v := Get("TYPED_DUR_UNSET").WithDefaultDuration(time.Second, failOnErrlog(t)); v != time.Second {
+		t.Errorf("unset: got %v, want default 1s", v)
+	}
+
+	setenv(t, "TYPED_DUR_OK", "250ms")
+	if v := Get("TYPED_DUR_OK").WithDefaultDuration(time.Second, failOnErrlog(t)); v != 250*time.Millisecond {
+		t.Errorf("parsed: got %v, want 250ms", v)
+	}
+
+	setenv(t, "TYPED_DUR_BAD", "not-a-duration")
+	var errored bool
+	v := Get("TYPED_DUR_BAD").WithDefaultDuration(time.Second, func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called for an unparseable duration")
+	}
+	if v != 0 {
+		t.Errorf("parse error: got %v, want zero value 0", v)
+	}
+}
+
+func TestWithDefaultURL(t *testing.T) {
+	def := mustParseURL(t, "https://default.example")
+
+	os.Unsetenv("TYPED_URL_UNSET")
+	if v := Get("TYPED_URL_UNSET").WithDefaultURL(def, failOnErrlog(t)); v != def {
+		t.Errorf("unset: got %v, want default %v", v, def)
+	}
+
+	setenv(t, "TYPED_URL_OK", "https://example.com/path")
+	v := Get("TYPED_URL_OK").WithDefaultURL(def, failOnErrlog(t))
+	if v == nil || v.Host != "example.com" {
+		t.Errorf("parsed: got %v, want host example.com", v)
+	}
+
+	setenv(t, "TYPED_URL_BAD", "://not-a-url")
+	var errored bool
+	bad := Get("TYPED_URL_BAD").WithDefaultURL(def, func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called for an unparseable URL")
+	}
+	if bad != nil {
+		t.Errorf("parse error: got %v, want nil (zero value), not the default", bad)
+	}
+}
+
+func TestRequiredInt(t *testing.T) {
+	os.Unsetenv("TYPED_REQ_INT_UNSET")
+	var errored bool
+	Get("TYPED_REQ_INT_UNSET").RequiredInt(func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called when unset")
+	}
+
+	setenv(t, "TYPED_REQ_INT_OK", "9")
+	if v := Get("TYPED_REQ_INT_OK").RequiredInt(failOnErrlog(t)); v != 9 {
+		t.Errorf("got %v, want 9", v)
+	}
+
+	setenv(t, "TYPED_REQ_INT_BAD", "nope")
+	errored = false
+	v := Get("TYPED_REQ_INT_BAD").RequiredInt(func(key string, err error) { errored = true })
+	if !errored || v != 0 {
+		t.Errorf("got v=%v errored=%v, want v=0 errored=true", v, errored)
+	}
+}
+
+func TestRequiredBool(t *testing.T) {
+	os.Unsetenv("TYPED_REQ_BOOL_UNSET")
+	var errored bool
+	Get("TYPED_REQ_BOOL_UNSET").RequiredBool(func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called when unset")
+	}
+
+	setenv(t, "TYPED_REQ_BOOL_OK", "true")
+	if v := Get("TYPED_REQ_BOOL_OK").RequiredBool(failOnErrlog(t)); v != true {
+		t.Errorf("got %v, want true", v)
+	}
+}
+
+func TestRequiredFloat64(t *testing.T) {
+	os.Unsetenv("TYPED_REQ_FLOAT_UNSET")
+	var errored bool
+	Get("TYPED_REQ_FLOAT_UNSET").RequiredFloat64(func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called when unset")
+	}
+
+	setenv(t, "TYPED_REQ_FLOAT_OK", "2.5")
+	if v := Get("TYPED_REQ_FLOAT_OK").RequiredFloat64(failOnErrlog(t)); v != 2.5 {
+		t.Errorf("got %v, want 2.5", v)
+	}
+}
+
+func TestRequiredDuration(t *testing.T) {
+	os.Unsetenv("TYPED_REQ_DUR_UNSET")
+	var errored bool
+	Get("TYPED_REQ_DUR_UNSET").RequiredDuration(func(key string, err error) { errored = true })
+	if !errored {
+		t.Errorf("expected errlog to be called when unset")
+	}
+
+	setenv(t, "TYPED_REQ_DUR_OK", "2s")
+	if v := Get("TYPED_REQ_DUR_OK").RequiredDuration(failOnErrlog(t)); v != 2*time.Second {
+		t.Errorf("got %v, want 2s", v)
+	}
+}
+
+func TestRequiredURL(t *testing.T) {
+	os.Unsetenv("TYPED_REQ_URL_UNSET")
+	var errored bool
+	v := Get("TYPED_REQ_URL_UNSET").RequiredURL(func(key string, err error) { errored = true })
+	if !errored || v != nil {
+		t.Errorf("got v=%v errored=%v, want v=nil errored=true", v, errored)
+	}
+
+	setenv(t, "TYPED_REQ_URL_OK", "https://example.com")
+	v = Get("TYPED_REQ_URL_OK").RequiredURL(failOnErrlog(t))
+	if v == nil || v.Host != "example.com" {
+		t.Errorf("got %v, want host example.com", v)
+	}
+
+	setenv(t, "TYPED_REQ_URL_BAD", "://not-a-url")
+	errored = false
+	v = Get("TYPED_REQ_URL_BAD").RequiredURL(func(key string, err error) { errored = true })
+	if !errored || v != nil {
+		t.Errorf("got v=%v errored=%v, want v=nil errored=true", v, errored)
+	}
+}
+
+func failOnErrlog(t *testing.T) func(key string, err error) {
+	t.Helper()
+	return func(key string, err error) {
+		t.Fatalf("unexpected errlog call for %q: %v", key, err)
+	}
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}